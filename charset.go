@@ -0,0 +1,149 @@
+package asciiconverter
+
+import (
+	"image"
+	"image/color"
+)
+
+// Charset selects how image pixels are packed into terminal cells.
+type Charset int
+
+const (
+	// CharsetASCII maps one pixel to one cell via a Mapper. This is the
+	// package's original behavior.
+	CharsetASCII Charset = iota
+	// CharsetHalfBlock packs two vertically-stacked pixels into one cell
+	// using '▀', with the top pixel as the foreground color and the
+	// bottom pixel as the background color. This doubles vertical
+	// resolution without using more terminal rows.
+	CharsetHalfBlock
+	// CharsetBraille packs a 2x4 block of pixels into one Unicode Braille
+	// character (U+2800-U+28FF), thresholding each sub-pixel's luminance
+	// against the block's average. This gives 2x horizontal and 4x
+	// vertical resolution over CharsetASCII.
+	CharsetBraille
+)
+
+// cellFactor returns how many source pixels, horizontally and vertically,
+// charset packs into a single terminal cell. resizeImg is scaled by this
+// factor before convertImageToAscii packs pixels back down to cells.
+func (c Charset) cellFactor() (dx, dy int) {
+	switch c {
+	case CharsetHalfBlock:
+		return 1, 2
+	case CharsetBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+const halfBlockUpper = '▀'
+
+// convertImageToHalfBlocks implements CharsetHalfBlock: every cell covers
+// two vertically-adjacent pixels, rendered as '▀' with the top pixel as the
+// foreground color and the bottom pixel as the background color.
+func convertImageToHalfBlocks(img image.Image) *AsciiFrame {
+	colors := sampleColors(img)
+	h := len(colors)
+	w := 0
+	if h > 0 {
+		w = len(colors[0])
+	}
+	rows := h / 2
+
+	frame := &AsciiFrame{
+		Runes:      make([][]rune, rows),
+		Colors:     make([][]color.RGBA, rows),
+		Background: make([][]color.RGBA, rows),
+		Charset:    CharsetHalfBlock,
+	}
+	for y := 0; y < rows; y++ {
+		runeRow := make([]rune, w)
+		fgRow := make([]color.RGBA, w)
+		bgRow := make([]color.RGBA, w)
+		for x := 0; x < w; x++ {
+			runeRow[x] = halfBlockUpper
+			fgRow[x] = colors[y*2][x]
+			bgRow[x] = colors[y*2+1][x]
+		}
+		frame.Runes[y] = runeRow
+		frame.Colors[y] = fgRow
+		frame.Background[y] = bgRow
+	}
+	return frame
+}
+
+// brailleBit maps each dot position in a 2-wide, 4-tall Braille cell to its
+// bit in the Unicode Braille Patterns block:
+//
+//	dot1 dot4
+//	dot2 dot5
+//	dot3 dot6
+//	dot7 dot8
+var brailleBit = [4][2]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// convertImageToBraille implements CharsetBraille: every cell covers a 2x4
+// block of pixels. Each sub-pixel is thresholded against the block's
+// average luminance to decide whether its dot is raised, and the cell is
+// colored with the average of all 8 pixels.
+func convertImageToBraille(img image.Image) *AsciiFrame {
+	colors := sampleColors(img)
+	h := len(colors)
+	w := 0
+	if h > 0 {
+		w = len(colors[0])
+	}
+	rows, cols := h/4, w/2
+
+	frame := &AsciiFrame{
+		Runes:   make([][]rune, rows),
+		Colors:  make([][]color.RGBA, rows),
+		Charset: CharsetBraille,
+	}
+	for cy := 0; cy < rows; cy++ {
+		runeRow := make([]rune, cols)
+		colorRow := make([]color.RGBA, cols)
+		for cx := 0; cx < cols; cx++ {
+			var luma [4][2]uint8
+			var sumR, sumG, sumB, sumLuma int
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					c := colors[cy*4+dy][cx*2+dx]
+					sumR += int(c.R)
+					sumG += int(c.G)
+					sumB += int(c.B)
+					l := uint8(luminanceFromRGBA(int(c.R), int(c.G), int(c.B)))
+					luma[dy][dx] = l
+					sumLuma += int(l)
+				}
+			}
+			avgLuma := sumLuma / 8
+
+			var bits uint8
+			for dy := 0; dy < 4; dy++ {
+				for dx := 0; dx < 2; dx++ {
+					if int(luma[dy][dx]) > avgLuma {
+						bits |= brailleBit[dy][dx]
+					}
+				}
+			}
+
+			runeRow[cx] = rune(0x2800 + int(bits))
+			colorRow[cx] = color.RGBA{
+				R: uint8(sumR / 8),
+				G: uint8(sumG / 8),
+				B: uint8(sumB / 8),
+				A: 0xff,
+			}
+		}
+		frame.Runes[cy] = runeRow
+		frame.Colors[cy] = colorRow
+	}
+	return frame
+}
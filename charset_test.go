@@ -0,0 +1,86 @@
+package asciiconverter
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestConvertImageToHalfBlocksSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 60), B: 10, A: 255})
+		}
+	}
+
+	frame, err := convertImageToAscii(img, nil, CharsetHalfBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frame.Runes) != 2 {
+		t.Fatalf("expected 2 rows (4 pixels / 2), got %d", len(frame.Runes))
+	}
+	if len(frame.Runes[0]) != 4 {
+		t.Fatalf("expected 4 cols, got %d", len(frame.Runes[0]))
+	}
+
+	lines := frame.Lines()
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 output lines, got %d", len(lines))
+	}
+	if n := strings.Count(lines[0], string(halfBlockUpper)); n != 4 {
+		t.Fatalf("expected 4 half-block glyphs in row (one per cell), got %d", n)
+	}
+}
+
+func TestConvertImageToBrailleSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			v := uint8(0)
+			if (x+y)%2 == 0 {
+				v = 255
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+
+	frame, err := convertImageToAscii(img, nil, CharsetBraille)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frame.Runes) != 2 {
+		t.Fatalf("expected 2 rows (8 pixels / 4), got %d", len(frame.Runes))
+	}
+	if len(frame.Runes[0]) != 4 {
+		t.Fatalf("expected 4 cols (8 pixels / 2), got %d", len(frame.Runes[0]))
+	}
+
+	lines := frame.Lines()
+	for _, line := range lines {
+		count := 0
+		for _, r := range line {
+			if r >= 0x2800 && r <= 0x28FF {
+				count++
+			}
+		}
+		if count != 4 {
+			t.Fatalf("expected 4 braille glyphs per row, got %d in %q", count, line)
+		}
+	}
+}
+
+func TestEncodeAsciiGifRejectsNonASCIICharset(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	frame, err := convertImageToAscii(img, nil, CharsetHalfBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asciiGif := &AsciiGif{Frame: frame, Lines: frame.Lines()}
+
+	if err := EncodeAsciiGif(&strings.Builder{}, []*AsciiGif{asciiGif}, 0); err == nil {
+		t.Fatal("expected EncodeAsciiGif to reject a CharsetHalfBlock frame, got nil error")
+	}
+}
@@ -0,0 +1,101 @@
+package asciiconverter
+
+import (
+	"bufio"
+	"context"
+	"image"
+	"image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"time"
+)
+
+// Options controls how an image or GIF is converted to ASCII. Width or
+// Height may be left at 0 to automatically preserve the source aspect ratio
+// (they count terminal cells, not source pixels, regardless of Charset).
+// Mapper selects the rune/character-mapping strategy used by CharsetASCII;
+// leave nil to use DefaultMapper. Charset selects the rendering mode;
+// the zero value is CharsetASCII.
+type Options struct {
+	Width   int
+	Height  int
+	Mapper  Mapper
+	Charset Charset
+}
+
+// DecodeAsciiImage decodes an image (PNG, JPEG or GIF) from r and converts it
+// to ASCII art, without requiring the source to be a filesystem path.
+func DecodeAsciiImage(r io.Reader, opts Options) ([]string, error) {
+	img, _, err := image.Decode(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	return asciiLinesFromImage(img, opts.Width, opts.Height, opts.Mapper, opts.Charset)
+}
+
+// DecodeAsciiGif decodes a GIF from r and converts every frame to ASCII art,
+// without requiring the source to be a filesystem path.
+func DecodeAsciiGif(r io.Reader, opts Options) ([]*AsciiGif, error) {
+	g, err := gif.DecodeAll(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	return asciiGifsFromDecoded(g, opts.Width, opts.Height, opts.Mapper, opts.Charset)
+}
+
+// StreamAsciiGif decodes a GIF from r and emits each frame on the returned
+// channel as soon as it has been composited and converted to ASCII, so a
+// caller can begin playback before every frame has been processed.
+// PrintGif does not read from this channel: it takes a complete
+// []*AsciiGif. The standard library only exposes whole-GIF decoding
+// (gif.DecodeAll), so r is still read to completion up front; the streaming
+// happens in the compositing/conversion stage, which is the expensive part
+// for large animations. Both channels are closed when the GIF is exhausted,
+// an error occurs, or ctx is canceled.
+func StreamAsciiGif(ctx context.Context, r io.Reader, opts Options) (<-chan *AsciiGif, <-chan error) {
+	frames := make(chan *AsciiGif)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(frames)
+		defer close(errs)
+
+		g, err := gif.DecodeAll(bufio.NewReader(r))
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		dx, dy := opts.Charset.cellFactor()
+		err = walkGifFrames(g, func(i int, frame image.Image) error {
+			resizedImg, err := resizeImg(frame, opts.Width*dx, opts.Height*dy)
+			if err != nil {
+				return err
+			}
+			asciiFrame, err := convertImageToAscii(resizedImg, opts.Mapper, opts.Charset)
+			if err != nil {
+				return err
+			}
+			asciiGif := &AsciiGif{
+				Frame: asciiFrame,
+				Lines: asciiFrame.Lines(),
+				// g.Delay is in centiseconds (1 unit = 10ms) per the GIF spec.
+				Delay:     time.Duration(g.Delay[i]) * 10 * time.Millisecond,
+				LoopCount: g.LoopCount,
+			}
+
+			select {
+			case frames <- asciiGif:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return frames, errs
+}
@@ -0,0 +1,98 @@
+package asciiconverter
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// cellW and cellH are the pixel dimensions of the bitmap font used to
+// rasterize each ASCII cell when encoding a GIF.
+var (
+	cellW = basicfont.Face7x13.Advance
+	cellH = basicfont.Face7x13.Height
+)
+
+// asciiGifPalette returns the 217-color palette EncodeAsciiGif quantizes
+// against: a black background plus the 216-color web-safe palette, which between them
+// cover terminal-style foreground colors well enough for Floyd-Steinberg to
+// dither smoothly.
+func asciiGifPalette() color.Palette {
+	pal := make(color.Palette, 0, len(palette.WebSafe)+1)
+	pal = append(pal, color.Black)
+	pal = append(pal, palette.WebSafe...)
+	return pal
+}
+
+// EncodeAsciiGif rasterizes a sequence of AsciiGif frames as a real,
+// playable GIF and writes it to w. Each character cell is drawn with a
+// bundled fixed-width bitmap font (basicfont.Face7x13) and colored with the
+// foreground color convertImageToAscii sampled from the source image, then
+// the frame is quantized to a 217-color palette with Floyd-Steinberg
+// dithering. loopCount follows gif.GIF.LoopCount (0 = loop forever).
+//
+// Only CharsetASCII frames are supported: basicfont.Face7x13 only has
+// glyphs for U+0020-U+007F and U+FFFD, so it can't draw the CharsetHalfBlock
+// ('▀') or CharsetBraille (U+2800-U+28FF) glyphs produced by the other
+// modes. Encoding a frame from either of those modes returns an error
+// rather than silently rasterizing blank cells.
+func EncodeAsciiGif(w io.Writer, frames []*AsciiGif, loopCount int) error {
+	if len(frames) == 0 {
+		return os.ErrInvalid
+	}
+
+	pal := asciiGifPalette()
+	out := &gif.GIF{LoopCount: loopCount}
+
+	for _, f := range frames {
+		if f.Frame == nil {
+			return fmt.Errorf("asciiconverter: frame has no rune/color data to encode")
+		}
+		if f.Frame.Charset != CharsetASCII {
+			return fmt.Errorf("asciiconverter: EncodeAsciiGif only supports CharsetASCII frames, got charset %d", f.Frame.Charset)
+		}
+
+		rows := len(f.Frame.Runes)
+		cols := 0
+		if rows > 0 {
+			cols = len(f.Frame.Runes[0])
+		}
+
+		rgba := image.NewRGBA(image.Rect(0, 0, cols*cellW, rows*cellH))
+		draw.Draw(rgba, rgba.Bounds(), image.Black, image.Point{}, draw.Src)
+
+		// f.Frame.Background is never read here: only CharsetASCII frames
+		// reach this point (enforced above), and CharsetASCII never
+		// populates Background, so encoding is foreground-only by
+		// construction.
+		drawer := &font.Drawer{Dst: rgba, Face: basicfont.Face7x13}
+		for y, runeRow := range f.Frame.Runes {
+			for x, ch := range runeRow {
+				drawer.Src = image.NewUniform(f.Frame.Colors[y][x])
+				drawer.Dot = fixed.Point26_6{
+					X: fixed.I(x * cellW),
+					Y: fixed.I(y*cellH + cellH - basicfont.Face7x13.Descent),
+				}
+				drawer.DrawString(string(ch))
+			}
+		}
+
+		paletted := image.NewPaletted(rgba.Bounds(), pal)
+		draw.FloydSteinberg.Draw(paletted, rgba.Bounds(), rgba, image.Point{})
+
+		out.Image = append(out.Image, paletted)
+		out.Delay = append(out.Delay, int(f.Delay/(10*time.Millisecond)))
+	}
+
+	return gif.EncodeAll(w, out)
+}
@@ -0,0 +1,71 @@
+package asciiconverter
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// TestWalkGifFramesDisposal builds a tiny synthetic GIF by hand (bypassing
+// the decoder) to pin down how walkGifFrames composites DisposalNone and
+// DisposalBackground: DisposalNone should leave a frame's pixels on the
+// canvas for subsequent frames, while DisposalBackground should clear a
+// frame's sub-rect back to transparent once it's been read.
+func TestWalkGifFramesDisposal(t *testing.T) {
+	pal := color.Palette{
+		color.RGBA{0, 0, 0, 0},
+		color.RGBA{255, 0, 0, 255},
+		color.RGBA{0, 0, 255, 255},
+	}
+
+	// Frame 0 paints (0,0) red and uses DisposalNone: it should stay on the
+	// canvas for later frames.
+	frame0 := image.NewPaletted(image.Rect(0, 0, 1, 1), pal)
+	frame0.SetColorIndex(0, 0, 1)
+
+	// Frame 1 paints (1,1) blue and uses DisposalBackground: its sub-rect
+	// should be cleared to transparent once the next frame is composited.
+	frame1 := image.NewPaletted(image.Rect(1, 1, 2, 2), pal)
+	frame1.SetColorIndex(1, 1, 2)
+
+	// Frame 2 is a no-op transparent frame, used only to observe the canvas
+	// state left behind by frame 1's disposal.
+	frame2 := image.NewPaletted(image.Rect(0, 0, 1, 1), pal)
+
+	g := &gif.GIF{
+		Image:    []*image.Paletted{frame0, frame1, frame2},
+		Delay:    []int{0, 0, 0},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalNone},
+		Config:   image.Config{Width: 2, Height: 2},
+	}
+
+	var composited []image.Image
+	if err := walkGifFrames(g, func(i int, frame image.Image) error {
+		composited = append(composited, frame)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(composited) != 3 {
+		t.Fatalf("expected 3 composited frames, got %d", len(composited))
+	}
+
+	if r, gg, b, a := composited[0].At(0, 0).RGBA(); r>>8 != 255 || gg>>8 != 0 || b>>8 != 0 || a>>8 != 255 {
+		t.Fatalf("frame 0 (0,0) should be opaque red, got (%d,%d,%d,%d)", r>>8, gg>>8, b>>8, a>>8)
+	}
+
+	if r, gg, b, _ := composited[1].At(1, 1).RGBA(); r>>8 != 0 || gg>>8 != 0 || b>>8 != 255 {
+		t.Fatalf("frame 1 (1,1) should be blue, got (%d,%d,%d)", r>>8, gg>>8, b>>8)
+	}
+	if r, gg, b, _ := composited[1].At(0, 0).RGBA(); r>>8 != 255 || gg>>8 != 0 || b>>8 != 0 {
+		t.Fatalf("frame 1 (0,0) should still be red via DisposalNone from frame 0, got (%d,%d,%d)", r>>8, gg>>8, b>>8)
+	}
+
+	if _, _, _, a := composited[2].At(1, 1).RGBA(); a>>8 != 0 {
+		t.Fatalf("frame 2 (1,1) should be transparent after frame 1's DisposalBackground, got alpha %d", a>>8)
+	}
+	if r, gg, b, _ := composited[2].At(0, 0).RGBA(); r>>8 != 255 || gg>>8 != 0 || b>>8 != 0 {
+		t.Fatalf("frame 2 (0,0) should still be red, got (%d,%d,%d)", r>>8, gg>>8, b>>8)
+	}
+}
@@ -2,9 +2,13 @@ package asciiconverter
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/gif"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -17,9 +21,53 @@ const charset = " .'`^\",:;Il!i><~+_-?][}{1)(|\\/tfjrxnuvczXYUJCLQ0OZmwqpdbkhao*
 
 var runes = []rune(charset)
 
+// AsciiFrame is the structured result of converting an image to ASCII: the
+// rune chosen for each cell alongside the color sampled from the source
+// pixel(s) it covers, before any ANSI escaping is applied. Keeping runes and colors
+// separate from the rendered strings lets other renderers (EncodeAsciiGif,
+// for instance) reuse the same conversion without re-parsing ANSI codes.
+// Background is only populated by modes that use two colors per cell (e.g.
+// CharsetHalfBlock); it is nil otherwise, and the background defaults to
+// black. Charset records which mode produced the frame, so renderers can
+// adjust how a cell's rune is drawn (see Lines).
+type AsciiFrame struct {
+	Runes      [][]rune
+	Colors     [][]color.RGBA
+	Background [][]color.RGBA
+	Charset    Charset
+}
+
+// Lines renders f as ANSI 24-bit colored strings, one per row, suitable for
+// printing straight to a terminal. CharsetASCII's glyphs are narrow relative
+// to a terminal cell, so they're written twice to fill it; CharsetHalfBlock
+// and CharsetBraille glyphs are already cell-width, so they're written once.
+func (f *AsciiFrame) Lines() []string {
+	wide := f.Charset == CharsetASCII
+	lines := make([]string, len(f.Runes))
+	for y, row := range f.Runes {
+		strBuild := strings.Builder{}
+		for x, ch := range row {
+			bg := color.RGBA{A: 0xff}
+			if f.Background != nil {
+				bg = f.Background[y][x]
+			}
+			writeColoredRune(&strBuild, f.Colors[y][x], bg, ch, wide)
+		}
+		strBuild.WriteString("\x1b[0m\n")
+		lines[y] = strBuild.String()
+	}
+	return lines
+}
+
 type AsciiGif struct {
+	Frame *AsciiFrame
 	Lines []string
 	Delay time.Duration
+
+	// LoopCount is the source GIF's gif.GIF.LoopCount: 0 means loop
+	// forever, -1 means show the animation once, and any other value N
+	// means loop the animation N+1 times in total.
+	LoopCount int
 }
 
 // openImg loads an image from the specified path and returns it as an image.Image.
@@ -32,20 +80,90 @@ func openImg(path string) (image.Image, error) {
 	return img, nil
 }
 
-// openGif loads a GIF file from the specified path and returns all frames and their delays.
-func openGif(path string) ([]*image.Paletted, []int, error) {
+// openGif loads a GIF file from the specified path and returns the decoded GIF,
+// including its frames, delays and disposal methods.
+func openGif(path string) (*gif.GIF, error) {
 	path = os.ExpandEnv(path)
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer f.Close()
 
 	g, err := gif.DecodeAll(f)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	return g, nil
+}
+
+// walkGifFrames renders each frame of g onto a full-size canvas, honoring the
+// graphic control extension's disposal method for every frame, and invokes fn
+// with the fully-composited image for each one in order. Sub-rectangle frames
+// (the common case for real-world GIFs) and DisposalNone/DisposalBackground/
+// DisposalPrevious are all handled so each frame matches what a GIF viewer
+// would display. Iteration stops early if fn returns an error.
+//
+// The decoded *image.Paletted frames already carry transparency in their
+// palette (the stdlib decoder zeroes the alpha of the transparent index), so
+// draw.Over correctly skips transparent pixels when compositing.
+func walkGifFrames(g *gif.GIF, fn func(i int, frame image.Image) error) error {
+	if g == nil || len(g.Image) == 0 {
+		return os.ErrInvalid
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+	var prevSnapshot *image.RGBA
+
+	for i, frame := range g.Image {
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		if disposal == gif.DisposalPrevious {
+			prevSnapshot = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		snapshot := cloneRGBA(canvas)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			if prevSnapshot != nil {
+				canvas = prevSnapshot
+			}
+		}
+
+		if err := fn(i, snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compositeGifFrames returns one fully-composited image per frame of g. See
+// walkGifFrames for how disposal methods are applied.
+func compositeGifFrames(g *gif.GIF) ([]image.Image, error) {
+	frames := make([]image.Image, 0, len(g.Image))
+	err := walkGifFrames(g, func(i int, frame image.Image) error {
+		frames = append(frames, frame)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return g.Image, g.Delay, nil
+	return frames, nil
+}
+
+// cloneRGBA returns an independent copy of src so later mutations of the
+// drawing canvas don't affect frames or snapshots already taken from it.
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
 }
 
 // resizeImg resizes the image to the specified width and height while maintaining aspect ratio if one dimension is zero.
@@ -76,88 +194,189 @@ func pickChar(gray uint8, runes []rune) rune {
 	return runes[idx]
 }
 
-// writeColoredRune writes a colored character (ANSI 24-bit color) to a string builder.
-func writeColoredRune(sb *strings.Builder, r, g, b uint8, ch rune) {
-	sb.WriteString("\x1b[48;2;0;0;0m\x1b[38;2;")
-	sb.WriteString(strconv.Itoa(int(r)))
+// writeColoredRune writes a character colored with 24-bit ANSI foreground
+// and background escapes to a string builder. When wide is true, ch is
+// written twice to square up glyphs (like the ASCII ramp) that are narrower
+// than a terminal cell; cell-width glyphs (half-block, Braille) pass wide as
+// false so they aren't stretched.
+func writeColoredRune(sb *strings.Builder, fg, bg color.RGBA, ch rune, wide bool) {
+	sb.WriteString("\x1b[48;2;")
+	sb.WriteString(strconv.Itoa(int(bg.R)))
+	sb.WriteByte(';')
+	sb.WriteString(strconv.Itoa(int(bg.G)))
+	sb.WriteByte(';')
+	sb.WriteString(strconv.Itoa(int(bg.B)))
+	sb.WriteString("m\x1b[38;2;")
+	sb.WriteString(strconv.Itoa(int(fg.R)))
 	sb.WriteByte(';')
-	sb.WriteString(strconv.Itoa(int(g)))
+	sb.WriteString(strconv.Itoa(int(fg.G)))
 	sb.WriteByte(';')
-	sb.WriteString(strconv.Itoa(int(b)))
+	sb.WriteString(strconv.Itoa(int(fg.B)))
 	sb.WriteByte('m')
 	sb.WriteRune(ch)
-	sb.WriteRune(ch)
+	if wide {
+		sb.WriteRune(ch)
+	}
+}
+
+// sampleColors reads every pixel of img into a [height][width] grid of
+// color.RGBA, the shared starting point for every conversion mode.
+func sampleColors(img image.Image) [][]color.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	colors := make([][]color.RGBA, h)
+	for y := 0; y < h; y++ {
+		row := make([]color.RGBA, w)
+		for x := 0; x < w; x++ {
+			p := img.At(bounds.Min.X+x, bounds.Min.Y+y)
+			r, g, b, _ := p.RGBA()
+			row[x] = color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0xff}
+		}
+		colors[y] = row
+	}
+	return colors
 }
 
-// convertImageToAscii converts an image to a slice of strings, where each string is a colored ASCII line.
-func convertImageToAscii(img image.Image) ([]string, error) {
+// convertImageToAscii converts an image into an AsciiFrame according to
+// mode, using mapper to pick each cell's rune in CharsetASCII mode
+// (DefaultMapper if mapper is nil; ignored by the other modes).
+func convertImageToAscii(img image.Image, mapper Mapper, mode Charset) (*AsciiFrame, error) {
 	if img == nil {
 		return nil, os.ErrInvalid
 	}
-	bounds := img.Bounds()
-	asciiImg := []string{}
-	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-		strBuild := strings.Builder{}
+	switch mode {
+	case CharsetHalfBlock:
+		return convertImageToHalfBlocks(img), nil
+	case CharsetBraille:
+		return convertImageToBraille(img), nil
+	default:
+		return convertImageToAsciiRamp(img, mapper), nil
+	}
+}
 
-		for x := bounds.Min.X; x < bounds.Max.X; x++ {
-			p := img.At(x, y)
-			r, g, b, _ := p.RGBA()
-			gray := uint8(luminanceFromRGBA(int(r>>8), int(g>>8), int(b>>8)))
-			char := pickChar(gray, runes)
-			writeColoredRune(&strBuild, uint8(r>>8), uint8(g>>8), uint8(b>>8), char)
+// convertImageToAsciiRamp is the CharsetASCII mode: one pixel maps to one
+// cell via mapper. It first samples color and BT.601 luminance for the
+// whole frame so edge-aware mappers can consult neighboring pixels, then
+// maps each cell in a second pass.
+func convertImageToAsciiRamp(img image.Image, mapper Mapper) *AsciiFrame {
+	if mapper == nil {
+		mapper = DefaultMapper
+	}
+	colors := sampleColors(img)
+	h := len(colors)
+	w := 0
+	if h > 0 {
+		w = len(colors[0])
+	}
 
+	luma := make([][]uint8, h)
+	for y := 0; y < h; y++ {
+		lumaRow := make([]uint8, w)
+		for x := 0; x < w; x++ {
+			c := colors[y][x]
+			lumaRow[x] = uint8(luminanceFromRGBA(int(c.R), int(c.G), int(c.B)))
 		}
-		strBuild.WriteString("\x1b[0m\n")
-		asciiImg = append(asciiImg, strBuild.String())
+		luma[y] = lumaRow
 	}
-	return asciiImg, nil
+
+	frame := &AsciiFrame{
+		Runes:   make([][]rune, h),
+		Colors:  colors,
+		Charset: CharsetASCII,
+	}
+	for y := 0; y < h; y++ {
+		runeRow := make([]rune, w)
+		for x := 0; x < w; x++ {
+			c := colors[y][x]
+			nx := [2]uint8{luma[y][clampIndex(x-1, w)], luma[y][clampIndex(x+1, w)]}
+			ny := [2]uint8{luma[clampIndex(y-1, h)][x], luma[clampIndex(y+1, h)][x]}
+			runeRow[x] = mapper.Map(c.R, c.G, c.B, nx, ny)
+		}
+		frame.Runes[y] = runeRow
+	}
+	return frame
 }
 
-// GetAsciiImage converts a single image from the specified path into ASCII art.
-// The output is a slice of strings representing the ASCII image.
-// width or height can be set to 0 to automatically preserve aspect ratio.
-func GetAsciiImage(path string, width, height int) ([]string, error) {
-	img, err := openImg(path)
-	if err != nil {
-		return nil, err
+// clampIndex clamps i into [0, n), replicating the edge pixel for
+// out-of-bounds neighbor lookups.
+func clampIndex(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
 	}
-	resizedImg, err := resizeImg(img, width, height)
+	return i
+}
+
+// asciiLinesFromImage resizes img, scaling for mode's cell factor, and
+// converts it to ASCII lines using mapper (nil for the default). It is the
+// shared core of GetAsciiImage and DecodeAsciiImage.
+func asciiLinesFromImage(img image.Image, width, height int, mapper Mapper, mode Charset) ([]string, error) {
+	dx, dy := mode.cellFactor()
+	resizedImg, err := resizeImg(img, width*dx, height*dy)
 	if err != nil {
 		return nil, err
 	}
-	asciiImg, err := convertImageToAscii(resizedImg)
+	frame, err := convertImageToAscii(resizedImg, mapper, mode)
 	if err != nil {
 		return nil, err
 	}
-	return asciiImg, nil
+	return frame.Lines(), nil
 }
 
-// GetAsciiGif converts a GIF from the specified path into a slice of AsciiGif frames.
-// Each frame contains ASCII art lines and its display delay.
-// width or height can be set to 0 to automatically preserve aspect ratio.
-func GetAsciiGif(path string, width, height int) ([]*AsciiGif, error) {
-	imgs, delays, err := openGif(path)
+// asciiGifsFromDecoded composites and converts every frame of a decoded GIF,
+// scaling for mode's cell factor and using mapper (nil for the default).
+// It is the shared core of GetAsciiGif and DecodeAsciiGif.
+func asciiGifsFromDecoded(g *gif.GIF, width, height int, mapper Mapper, mode Charset) ([]*AsciiGif, error) {
+	frames, err := compositeGifFrames(g)
 	if err != nil {
 		return nil, err
 	}
-	var asciiGifs []*AsciiGif
-	for i, img := range imgs {
-		resizedImg, err := resizeImg(img, width, height)
+	dx, dy := mode.cellFactor()
+	asciiGifs := make([]*AsciiGif, 0, len(frames))
+	for i, frame := range frames {
+		resizedImg, err := resizeImg(frame, width*dx, height*dy)
 		if err != nil {
 			return nil, err
 		}
-		asciiImg, err := convertImageToAscii(resizedImg)
+		asciiFrame, err := convertImageToAscii(resizedImg, mapper, mode)
 		if err != nil {
 			return nil, err
 		}
 		asciiGifs = append(asciiGifs, &AsciiGif{
-			Lines: asciiImg,
-			Delay: time.Duration(delays[i]) * time.Millisecond,
+			Frame: asciiFrame,
+			Lines: asciiFrame.Lines(),
+			// g.Delay is in centiseconds (1 unit = 10ms) per the GIF spec.
+			Delay:     time.Duration(g.Delay[i]) * 10 * time.Millisecond,
+			LoopCount: g.LoopCount,
 		})
 	}
 	return asciiGifs, nil
 }
 
+// GetAsciiImage converts a single image from the specified path into ASCII art.
+// The output is a slice of strings representing the ASCII image.
+// width or height can be set to 0 to automatically preserve aspect ratio.
+func GetAsciiImage(path string, width, height int) ([]string, error) {
+	img, err := openImg(path)
+	if err != nil {
+		return nil, err
+	}
+	return asciiLinesFromImage(img, width, height, nil, CharsetASCII)
+}
+
+// GetAsciiGif converts a GIF from the specified path into a slice of AsciiGif frames.
+// Each frame contains ASCII art lines and its display delay.
+// width or height can be set to 0 to automatically preserve aspect ratio.
+func GetAsciiGif(path string, width, height int) ([]*AsciiGif, error) {
+	g, err := openGif(path)
+	if err != nil {
+		return nil, err
+	}
+	return asciiGifsFromDecoded(g, width, height, nil, CharsetASCII)
+}
+
 // PrintImg prints a single ASCII image to the terminal.
 func PrintImg(img []string) {
 	for _, line := range img {
@@ -165,9 +384,25 @@ func PrintImg(img []string) {
 	}
 }
 
-// PrintGif plays an ASCII GIF animation in the terminal.
-// The parameter 'loop' specifies the number of times the animation should repeat.
-func PrintGif(frames []*AsciiGif, loop int) {
+// PlayOptions controls how PrintGif plays back an AsciiGif animation.
+type PlayOptions struct {
+	// Loop sets how many times to repeat the animation. -1 means use the
+	// source GIF's own LoopCount (frames[0].LoopCount) instead of an
+	// explicit value; once resolved, that value follows the same
+	// convention as AsciiGif.LoopCount: 0 loops forever, -1 plays once,
+	// and N loops the animation N+1 times in total.
+	Loop int
+	// Speed scales playback speed; 1.0 plays at the GIF's native speed,
+	// 2.0 plays twice as fast. Values <= 0 are treated as 1.0.
+	Speed float64
+	// Writer is where frames are written. Defaults to os.Stdout if nil.
+	Writer io.Writer
+	// Ctx, if non-nil, lets callers cancel playback early.
+	Ctx context.Context
+}
+
+// PrintGif plays an ASCII GIF animation in the terminal according to opts.
+func PrintGif(frames []*AsciiGif, opts PlayOptions) {
 	const (
 		clearScreen = "\033[2J"
 		cursorHome  = "\033[H"
@@ -176,31 +411,69 @@ func PrintGif(frames []*AsciiGif, loop int) {
 		resetColor  = "\033[0m"
 	)
 
-	fmt.Print(hideCursor, clearScreen, cursorHome)
-	defer fmt.Print(showCursor, resetColor)
+	if len(frames) == 0 {
+		return
+	}
+
+	out := opts.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	speed := opts.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	loop := opts.Loop
+	if loop == -1 {
+		loop = frames[0].LoopCount
+	}
 
-	w := bufio.NewWriter(os.Stdout)
+	fmt.Fprint(out, hideCursor, clearScreen, cursorHome)
+	defer fmt.Fprint(out, showCursor, resetColor)
+
+	w := bufio.NewWriter(out)
 	defer w.Flush()
 
 	minDelay := 33 * time.Millisecond // minimum frame delay to avoid flickering
 
-	for {
+	for played := 0; ; played++ {
 		for _, f := range frames {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
 			fmt.Fprint(w, cursorHome)
 			for _, line := range f.Lines {
 				w.WriteString(line)
 			}
 			w.Flush()
 
-			d := 10 * f.Delay
+			d := time.Duration(float64(f.Delay) / speed)
 			if d <= 0 {
 				d = minDelay
 			}
-			time.Sleep(d)
+
+			select {
+			case <-time.After(d):
+			case <-ctx.Done():
+				return
+			}
 		}
-		loop--
-		if loop <= 0 {
-			break
+
+		switch {
+		case loop == -1:
+			return // source's LoopCount says "show once"
+		case loop == 0:
+			// loop forever (either explicitly, or via the source's LoopCount)
+		case played >= loop:
+			return
 		}
 	}
 }
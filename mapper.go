@@ -0,0 +1,102 @@
+package asciiconverter
+
+import "math"
+
+// Mapper picks the rune for one ASCII cell given its color and the luminance
+// of its immediate neighbors. nx holds the luminance of the pixels to the
+// left and right ([2]uint8{left, right}); ny holds the luminance of the
+// pixels above and below ([2]uint8{above, below}). Edge-aware mappers use
+// the neighbors to detect gradients; ramp-based mappers can ignore them.
+type Mapper interface {
+	Map(r, g, b uint8, nx, ny [2]uint8) rune
+}
+
+// DefaultMapper is used wherever a nil Mapper is supplied, reproducing the
+// package's original pixel-mapping behavior (BT.601 luminance over the
+// built-in charset ramp).
+var DefaultMapper Mapper = NewBT601Mapper()
+
+// RampMapper maps a pixel's luminance onto a fixed character ramp using a
+// pluggable luminance formula. It ignores neighboring pixels.
+type RampMapper struct {
+	Luminance func(r, g, b int) float64
+	Runes     []rune
+}
+
+// Map implements Mapper.
+func (m *RampMapper) Map(r, g, b uint8, _, _ [2]uint8) rune {
+	gray := uint8(m.Luminance(int(r), int(g), int(b)))
+	return pickChar(gray, m.Runes)
+}
+
+// NewBT601Mapper returns a Mapper using the ITU-R BT.601 luminance formula
+// and the package's default character ramp.
+func NewBT601Mapper() *RampMapper {
+	return &RampMapper{Luminance: luminanceFromRGBA, Runes: runes}
+}
+
+// NewBT709Mapper returns a Mapper using the ITU-R BT.709 luminance formula,
+// which weighs green more heavily (and red and blue less) than BT.601 and
+// better matches how modern
+// displays represent brightness.
+func NewBT709Mapper() *RampMapper {
+	return &RampMapper{Luminance: bt709LuminanceFromRGBA, Runes: runes}
+}
+
+// NewCharsetMapper returns a Mapper that ramps across a caller-supplied set
+// of characters, ordered darkest to brightest, instead of the package's
+// default charset.
+func NewCharsetMapper(charset string) *RampMapper {
+	return &RampMapper{Luminance: luminanceFromRGBA, Runes: []rune(charset)}
+}
+
+// bt709LuminanceFromRGBA calculates luminance using the ITU-R BT.709 formula.
+func bt709LuminanceFromRGBA(r, g, b int) float64 {
+	return 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+}
+
+// edgeGlyphs are indexed by quantizeGradientAngle's return value.
+var edgeGlyphs = [4]rune{'|', '_', '/', '\\'}
+
+// EdgeMapper is an edge-aware Mapper. It computes a central-difference
+// luminance gradient from each cell's immediate left/right and above/below
+// neighbors (not a full Sobel kernel) and, where the gradient magnitude
+// crosses Threshold, emits a directional glyph instead of a ramp character.
+// Cells without a strong gradient fall back to Fallback.
+type EdgeMapper struct {
+	// Threshold is the minimum gradient magnitude, on the 0-255 luminance
+	// scale, considered an edge.
+	Threshold float64
+	// Fallback maps non-edge cells. DefaultMapper is used if nil.
+	Fallback Mapper
+}
+
+// Map implements Mapper.
+func (m *EdgeMapper) Map(r, g, b uint8, nx, ny [2]uint8) rune {
+	gx := float64(nx[1]) - float64(nx[0])
+	gy := float64(ny[1]) - float64(ny[0])
+	if math.Hypot(gx, gy) > m.Threshold {
+		return edgeGlyphs[quantizeGradientAngle(gx, gy)]
+	}
+	fallback := m.Fallback
+	if fallback == nil {
+		fallback = DefaultMapper
+	}
+	return fallback.Map(r, g, b, nx, ny)
+}
+
+// quantizeGradientAngle buckets atan2(gy, gx) into 4 bins matching
+// edgeGlyphs: vertical ('|'), horizontal ('_'), and the two diagonals.
+func quantizeGradientAngle(gx, gy float64) int {
+	deg := math.Mod(math.Atan2(gy, gx)*180/math.Pi+360, 180)
+	switch {
+	case deg < 22.5, deg >= 157.5:
+		return 0 // '|'
+	case deg < 67.5:
+		return 2 // '/'
+	case deg < 112.5:
+		return 1 // '_'
+	default:
+		return 3 // '\\'
+	}
+}